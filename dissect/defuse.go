@@ -41,6 +41,79 @@ func (r *Reader) getAlivePlayersByTeam(teamIndex int) []string {
 	return alive
 }
 
+// resolveDefuserCarrier fuses the signals available on Y10S4+ (where the
+// packet no longer carries the player's DissectID) to find who is holding
+// the defuser, in order of confidence:
+//  1. the scoreboard hint (r.lastDefuserFromScoreboard), set from the most
+//     recent scoreboard packet naming a defuser carrier
+//  2. the most recent LocateObjective actor, which pings near the bomb -
+//     readMatchFeedback only emits LocateObjective for CodeVersion < Y9S1
+//     (see the TODO there), so on Y10S4+ this is currently a no-op; it's
+//     kept as the documented fallback order for when that packet format is
+//     understood, rather than silently dropping the signal
+//  3. the alive-player set on targetRole's team, only usable when exactly
+//     one player is left
+func (r *Reader) resolveDefuserCarrier(targetRole TeamRole) (playerIndex int, source string) {
+	if r.lastDefuserFromScoreboard != "" {
+		if idx := r.PlayerIndexByUsername(r.lastDefuserFromScoreboard); idx >= 0 {
+			return idx, DefuserSourceScoreboard
+		}
+	}
+	if username := r.lastLocateObjectiveActor(); username != "" {
+		if idx := r.PlayerIndexByUsername(username); idx >= 0 {
+			return idx, DefuserSourceLocateObjective
+		}
+	}
+	teamIndex := r.getTeamByRole(targetRole)
+	if teamIndex >= 0 {
+		alive := r.getAlivePlayersByTeam(teamIndex)
+		if len(alive) == 1 {
+			for i, p := range r.Header.Players {
+				if p.Username == alive[0] {
+					return i, DefuserSourceSingleAlive
+				}
+			}
+		}
+	}
+	return -1, DefuserSourceUnknown
+}
+
+// lastLocateObjectiveActor returns the username behind the most recent
+// LocateObjective ping, i.e. the player who most recently pinged near the
+// bomb.
+func (r *Reader) lastLocateObjectiveActor() string {
+	for i := len(r.MatchFeedback) - 1; i >= 0; i-- {
+		if u := r.MatchFeedback[i]; u.Type == LocateObjective && u.Username != "" {
+			return u.Username
+		}
+	}
+	return ""
+}
+
+// readScoreboard parses a scoreboard packet, which periodically reports the
+// most recent killer and defuser-carrier usernames independent of the
+// Kill/DefuserTimer packets. It's the highest-confidence signal for both
+// r.lastKillerFromScoreboard (cross-checked against Kill usernames) and
+// r.lastDefuserFromScoreboard (consumed by resolveDefuserCarrier on
+// Y10S4+, where the defuser-timer packet no longer carries a player ID).
+func readScoreboard(r *Reader) error {
+	killer, err := r.String()
+	if err != nil {
+		return err
+	}
+	defuser, err := r.String()
+	if err != nil {
+		return err
+	}
+	if killer != "" {
+		r.lastKillerFromScoreboard = killer
+	}
+	if defuser != "" {
+		r.lastDefuserFromScoreboard = defuser
+	}
+	return nil
+}
+
 func readDefuserTimer(r *Reader) error {
 	timer, err := r.String()
 	if err != nil {
@@ -48,30 +121,18 @@ func readDefuserTimer(r *Reader) error {
 	}
 
 	var playerIndex int = -1
+	source := ""
 
 	if r.Header.CodeVersion >= Y10S4 {
-		// Y10S4 changed packet structure - player DissectID is no longer included
-		// Try to infer from team roles: attackers plant, defenders disable
+		// Y10S4 changed packet structure - player DissectID is no longer
+		// included, so the carrier has to be inferred from other signals.
 		var targetRole TeamRole
 		if r.planted {
 			targetRole = Defense // Defender is disabling
 		} else {
 			targetRole = Attack // Attacker is planting
 		}
-		
-		teamIndex := r.getTeamByRole(targetRole)
-		if teamIndex >= 0 {
-			alive := r.getAlivePlayersByTeam(teamIndex)
-			if len(alive) == 1 {
-				// Only one player alive on that team - must be them
-				for i, p := range r.Header.Players {
-					if p.Username == alive[0] {
-						playerIndex = i
-						break
-					}
-				}
-			}
-		}
+		playerIndex, source = r.resolveDefuserCarrier(targetRole)
 	} else {
 		if err = r.Skip(34); err != nil {
 			return err
@@ -93,8 +154,9 @@ func readDefuserTimer(r *Reader) error {
 			Username:      r.Header.Players[playerIndex].Username,
 			Time:          r.timeRaw,
 			TimeInSeconds: r.time,
+			Source:        source,
 		}
-		r.MatchFeedback = append(r.MatchFeedback, u)
+		r.appendUpdate(u)
 		log.Debug().Interface("match_update", u).Send()
 		r.lastDefuserPlayerIndex = playerIndex
 	}
@@ -107,20 +169,24 @@ func readDefuserTimer(r *Reader) error {
 	if !r.planted {
 		a = DefuserPlantComplete
 		r.planted = true
+		r.clock.PlantTime = r.time
+		r.enterPhase(PhasePlanted)
 	}
 	
 	username := ""
 	if r.lastDefuserPlayerIndex >= 0 && r.lastDefuserPlayerIndex < len(r.Header.Players) {
 		username = r.Header.Players[r.lastDefuserPlayerIndex].Username
+	} else if a == DefuserPlantComplete {
+		r.addDiagnostic(DiagDefusePlantWithoutStart, Warning, "")
 	}
-	
+
 	u := MatchUpdate{
 		Type:          a,
 		Username:      username,
 		Time:          r.timeRaw,
 		TimeInSeconds: r.time,
 	}
-	r.MatchFeedback = append(r.MatchFeedback, u)
+	r.appendUpdate(u)
 	log.Debug().Interface("match_update", u).Send()
 	return nil
 }
@@ -0,0 +1,98 @@
+package dissect
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DiagnosticSeverity classifies how serious a Diagnostic is.
+type DiagnosticSeverity int
+
+const (
+	Info DiagnosticSeverity = iota
+	Warning
+	Error
+)
+
+func (s DiagnosticSeverity) String() string {
+	switch s {
+	case Warning:
+		return "Warning"
+	case Error:
+		return "Error"
+	default:
+		return "Info"
+	}
+}
+
+func (s DiagnosticSeverity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// DiagnosticCode identifies the specific check that produced a Diagnostic.
+type DiagnosticCode string
+
+const (
+	DiagKillUsernameEmpty       DiagnosticCode = "kill_username_empty"
+	DiagDuplicateKillFiltered   DiagnosticCode = "duplicate_kill_filtered"
+	DiagMatchFeedbackInvalid    DiagnosticCode = "match_feedback_invalid"
+	DiagDefusePlantWithoutStart DiagnosticCode = "defuse_plant_without_start"
+	DiagGhostPlayer             DiagnosticCode = "ghost_player"
+	DiagTimerNonMonotonic       DiagnosticCode = "timer_non_monotonic"
+)
+
+// Diagnostic is a single machine-readable parser warning, recorded alongside
+// the zerolog debug lines in readMatchFeedback/readDefuserTimer so callers
+// can act on it without grepping logs - e.g. a
+// `dissect verify` subcommand that exits non-zero on any Error.
+type Diagnostic struct {
+	Code     DiagnosticCode     `json:"code"`
+	Severity DiagnosticSeverity `json:"severity"`
+	Time     string             `json:"time,omitempty"`
+	Context  string             `json:"context,omitempty"`
+}
+
+// Diagnostics returns every Diagnostic recorded while parsing r.
+func (r *Reader) Diagnostics() []Diagnostic {
+	return r.diagnostics
+}
+
+// HasErrors reports whether any recorded Diagnostic is Error severity.
+func (r *Reader) HasErrors() bool {
+	for _, d := range r.diagnostics {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reader) addDiagnostic(code DiagnosticCode, severity DiagnosticSeverity, context string) {
+	r.diagnostics = append(r.diagnostics, Diagnostic{
+		Code:     code,
+		Severity: severity,
+		Time:     r.timeRaw,
+		Context:  context,
+	})
+}
+
+// checkGhostPlayer flags a username referenced by a kill/death that isn't
+// in Header.Players - the replay mentioned a player dissect never saw in
+// the roster.
+func (r *Reader) checkGhostPlayer(username string) {
+	if username == "" || r.PlayerIndexByUsername(username) >= 0 {
+		return
+	}
+	r.addDiagnostic(DiagGhostPlayer, Warning, username)
+}
+
+// checkTimerMonotonic flags a clock value that jumps upward while already
+// in overtime. RoundClock.observe expects at most one such jump per round
+// (the defuser-plant reset); a second one mid-round, before a new round
+// resets the clock, means the timeline itself is inconsistent.
+func (r *Reader) checkTimerMonotonic(t float64) {
+	if r.clock.Overtime && r.clock.lastTimeSeen >= 0 && t > r.clock.lastTimeSeen+5 {
+		r.addDiagnostic(DiagTimerNonMonotonic, Warning,
+			fmt.Sprintf("time jumped from %.2f to %.2f while already in overtime", r.clock.lastTimeSeen, t))
+	}
+}
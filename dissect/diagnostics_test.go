@@ -0,0 +1,54 @@
+package dissect
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestCheckGhostPlayerRecordsDiagnostic covers one of the new integrity
+// checks: a username referenced by a kill/death that isn't in
+// Header.Players is flagged as DiagGhostPlayer.
+func TestCheckGhostPlayerRecordsDiagnostic(t *testing.T) {
+	r := &Reader{Header: Header{Players: []Player{{Username: "alice"}}}}
+
+	r.checkGhostPlayer("alice")
+	if len(r.Diagnostics()) != 0 {
+		t.Fatalf("expected no diagnostic for a known player, got %v", r.Diagnostics())
+	}
+
+	r.checkGhostPlayer("mallory")
+	diags := r.Diagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic for an unknown player, got %d", len(diags))
+	}
+	if diags[0].Code != DiagGhostPlayer || diags[0].Severity != Warning {
+		t.Fatalf("unexpected diagnostic: %+v", diags[0])
+	}
+	if r.HasErrors() {
+		t.Fatalf("a Warning-severity diagnostic should not count as an error")
+	}
+}
+
+// TestDiagnosticJSONRoundTrip covers the CLI-facing JSON export: severity
+// marshals to its string name, not the underlying int.
+func TestDiagnosticJSONRoundTrip(t *testing.T) {
+	d := Diagnostic{Code: DiagTimerNonMonotonic, Severity: Error, Time: "1:30", Context: "jumped"}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out struct {
+		Code     string `json:"code"`
+		Severity string `json:"severity"`
+		Time     string `json:"time"`
+		Context  string `json:"context"`
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Code != string(DiagTimerNonMonotonic) || out.Severity != "Error" {
+		t.Fatalf("unexpected round trip: %+v", out)
+	}
+}
@@ -23,6 +23,8 @@ const (
 	OperatorSwap
 	Battleye
 	PlayerLeave
+	PhaseEnter
+	TeamKill
 	Other
 )
 
@@ -35,9 +37,54 @@ type MatchUpdate struct {
 	TimeInSeconds          float64         `json:"timeInSeconds"`
 	Message                string          `json:"message,omitempty"`
 	Operator               Operator        `json:"operator,omitempty"`
+	// Source names which signal resolved Username on the
+	// DefuserPlantStart/DefuserDisableStart updates of Y10S4+ replays, where
+	// the carrier is inferred rather than read off the packet. It is empty
+	// on every other update, including the matching Complete updates, whose
+	// Username reuses the carrier resolved for the Start update.
+	Source                 string `json:"source,omitempty"`
 	usernameFromScoreboard string
 }
 
+// Defuser carrier resolution sources, from most to least confident.
+const (
+	DefuserSourceScoreboard      = "scoreboard"
+	DefuserSourceLocateObjective = "locate-objective"
+	DefuserSourceSingleAlive     = "single-alive"
+	DefuserSourceUnknown         = "unknown"
+)
+
+func (i MatchUpdateType) String() string {
+	switch i {
+	case Kill:
+		return "Kill"
+	case Death:
+		return "Death"
+	case DefuserPlantStart:
+		return "DefuserPlantStart"
+	case DefuserPlantComplete:
+		return "DefuserPlantComplete"
+	case DefuserDisableStart:
+		return "DefuserDisableStart"
+	case DefuserDisableComplete:
+		return "DefuserDisableComplete"
+	case LocateObjective:
+		return "LocateObjective"
+	case OperatorSwap:
+		return "OperatorSwap"
+	case Battleye:
+		return "Battleye"
+	case PlayerLeave:
+		return "PlayerLeave"
+	case PhaseEnter:
+		return "PhaseEnter"
+	case TeamKill:
+		return "TeamKill"
+	default:
+		return "Other"
+	}
+}
+
 func (i MatchUpdateType) MarshalJSON() (text []byte, err error) {
 	return json.Marshal(stringerIntMarshal{
 		Name: i.String(),
@@ -71,6 +118,7 @@ func readMatchFeedback(r *Reader) error {
 			return err
 		}
 		if valid != 4 {
+			r.addDiagnostic(DiagMatchFeedbackInvalid, Error, "")
 			return errors.New("match feedback failed valid check")
 		}
 		if err := r.Skip(24); err != nil {
@@ -104,6 +152,7 @@ func readMatchFeedback(r *Reader) error {
 		empty := len(username) == 0
 		if empty {
 			log.Debug().Str("warn", "kill username empty").Send()
+			r.addDiagnostic(DiagKillUsernameEmpty, Info, "")
 		}
 		if err = r.Skip(15); err != nil {
 			return err
@@ -121,7 +170,7 @@ func readMatchFeedback(r *Reader) error {
 					Time:          r.timeRaw,
 					TimeInSeconds: r.time,
 				}
-				r.MatchFeedback = append(r.MatchFeedback, u)
+				r.appendUpdate(u)
 				log.Debug().Interface("match_update", u).Send()
 				log.Debug().Msg("kill username empty because of death")
 			}
@@ -146,99 +195,34 @@ func readMatchFeedback(r *Reader) error {
 			*headshotPtr = true
 		}
 		u.Headshot = headshotPtr
-		// Validate teams: killer and target must be on different teams
-		killerIdx := r.PlayerIndexByUsername(u.Username)
-		targetIdx := r.PlayerIndexByUsername(u.Target)
-		if killerIdx >= 0 && targetIdx >= 0 {
-			killerTeam := r.Header.Players[killerIdx].TeamIndex
-			targetTeam := r.Header.Players[targetIdx].TeamIndex
-			if killerTeam == targetTeam {
-				log.Debug().
-					Str("killer", u.Username).
-					Str("target", u.Target).
-					Int("team", killerTeam).
-					Msg("kill filtered (same team)")
-				return nil
-			}
+		r.checkGhostPlayer(u.Username)
+		r.checkGhostPlayer(u.Target)
+		r.checkTimerMonotonic(u.TimeInSeconds)
+		if newRound := r.clock.observe(u.TimeInSeconds); newRound {
+			r.resetClock()
 		}
-		// Filter duplicate kills: if the target has already been killed in this round,
-		// it's a duplicate (replays sometimes emit the same kill event multiple times,
-		// especially after defuser plant when the timer resets).
-		// Exception: overtime after defuser allows ONE "re-kill" per target (DBNO revive scenario).
-		// We detect overtime by checking if time jumps up (timer reset after defuser plant).
-		// 
-		// Special case: kills that occur exactly at defuser plant time are "plant-boundary kills"
-		// and are more likely to be duplicated by the replay system. For these, we require
-		// the re-kill to be by a DIFFERENT killer to count as legitimate.
-		inOvertime := false
-		defuserPlantTime := float64(-1)
-		for i := len(r.MatchFeedback) - 1; i >= 0; i-- {
-			val := r.MatchFeedback[i]
-			// Track defuser plant time
-			if val.Type == DefuserPlantComplete {
-				defuserPlantTime = val.TimeInSeconds
-			}
-			// Detect if we're in overtime: time has jumped up (timer reset after defuser)
-			// Check ALL events for time jumps, not just kills
-			if u.TimeInSeconds > val.TimeInSeconds+5 {
-				inOvertime = true
-			}
-			// Only check kills/deaths for duplicate detection
-			if val.Type != Kill && val.Type != Death {
-				continue
-			}
-			// Check if this target has already been killed/died in this round
-			targetAlreadyDead := (val.Type == Kill && val.Target == u.Target) ||
-				(val.Type == Death && val.Username == u.Target)
-			if targetAlreadyDead {
-				sameKiller := val.Type == Kill && val.Username == u.Username
-				// Check if original kill was at plant-boundary (at or within 1 second AFTER defuser plant)
-				// Note: time counts DOWN, so val.TimeInSeconds <= defuserPlantTime means kill was at/after plant
-				isPlantBoundaryKill := defuserPlantTime >= 0 && val.TimeInSeconds <= defuserPlantTime && val.TimeInSeconds >= defuserPlantTime-1
-				// In overtime, allow re-kills with these conditions:
-				// - If same killer: only allow if NOT a plant-boundary kill (those are likely duplicates)
-				// - If different killer: always allow (DBNO finished by teammate, now actually killed)
-				if inOvertime {
-					if !sameKiller {
-						log.Debug().
-							Str("killer", u.Username).
-							Str("target", u.Target).
-							Str("original_killer", val.Username).
-							Float64("existing_time", val.TimeInSeconds).
-							Float64("new_time", u.TimeInSeconds).
-							Msg("overtime re-kill allowed (different killer)")
-						break
-					}
-					if !isPlantBoundaryKill {
-						log.Debug().
-							Str("killer", u.Username).
-							Str("target", u.Target).
-							Float64("existing_time", val.TimeInSeconds).
-							Float64("new_time", u.TimeInSeconds).
-							Float64("defuser_plant_time", defuserPlantTime).
-							Msg("overtime re-kill allowed (same killer, not plant-boundary)")
-						break
-					}
-				}
-				log.Debug().
-					Str("killer", u.Username).
-					Str("target", u.Target).
-					Float64("existing_time", val.TimeInSeconds).
-					Float64("new_time", u.TimeInSeconds).
-					Bool("plant_boundary", isPlantBoundaryKill).
-					Msg("duplicate kill filtered (target already dead)")
-				return nil
-			}
+		if r.clock.Phase == PhasePrep {
+			// The first kill ends prep and starts the action phase. Death
+			// updates return above without touching the clock or phase.
+			r.enterPhase(PhaseAction)
+		}
+		if r.clock.Overtime {
+			r.enterPhase(PhaseOvertime)
 		}
 		// removing the elimination username for now
 		if r.lastKillerFromScoreboard != username {
 			u.usernameFromScoreboard = r.lastKillerFromScoreboard
 		}
-		r.MatchFeedback = append(r.MatchFeedback, u)
-		log.Debug().Interface("match_update", u).Send()
+		if filtered, ok := r.runFilters(u); ok {
+			r.appendUpdate(filtered)
+			log.Debug().Interface("match_update", filtered).Send()
+		}
 		return nil
 	}
-	// TODO: Y9S1 may have removed or modified other match feedback options
+	// TODO: Y9S1 may have removed or modified other match feedback options.
+	// Until this is understood, LocateObjective (among others) is never
+	// emitted on Y9S1+, which makes the LocateObjective fallback in
+	// resolveDefuserCarrier a no-op on Y10S4+ replays.
 	if r.Header.CodeVersion >= Y9S1 {
 		return nil
 	}
@@ -271,7 +255,7 @@ func readMatchFeedback(r *Reader) error {
 		TimeInSeconds: r.time,
 		Message:       msg,
 	}
-	r.MatchFeedback = append(r.MatchFeedback, u)
+	r.appendUpdate(u)
 	log.Debug().Interface("match_update", u).Send()
 	return nil
 }
@@ -0,0 +1,192 @@
+package dissect
+
+import "github.com/rs/zerolog/log"
+
+// FeedbackFilter inspects a newly parsed MatchUpdate against everything
+// already accepted onto MatchFeedback (prev) and decides whether to keep
+// it. Returning ok=false drops next entirely; returning a modified next
+// lets a filter rewrite an update (e.g. retyping a same-team kill) before
+// later filters and appendUpdate see it.
+type FeedbackFilter interface {
+	Apply(prev []MatchUpdate, next MatchUpdate) (MatchUpdate, bool)
+}
+
+// SameTeamFilter drops kills where the killer and target are on the same
+// team - friendly fire the replay logs but that isn't a real enemy kill.
+type SameTeamFilter struct {
+	Reader *Reader
+}
+
+func (f SameTeamFilter) Apply(prev []MatchUpdate, next MatchUpdate) (MatchUpdate, bool) {
+	if next.Type != Kill {
+		return next, true
+	}
+	killerIdx := f.Reader.PlayerIndexByUsername(next.Username)
+	targetIdx := f.Reader.PlayerIndexByUsername(next.Target)
+	if killerIdx < 0 || targetIdx < 0 {
+		return next, true
+	}
+	if f.Reader.Header.Players[killerIdx].TeamIndex != f.Reader.Header.Players[targetIdx].TeamIndex {
+		return next, true
+	}
+	log.Debug().
+		Str("killer", next.Username).
+		Str("target", next.Target).
+		Int("team", f.Reader.Header.Players[killerIdx].TeamIndex).
+		Msg("kill filtered (same team)")
+	return next, false
+}
+
+// TeamKillFilter is an alternative to SameTeamFilter: instead of dropping a
+// same-team kill it retypes the update as TeamKill so callers can still see
+// it happened. Swap it in with:
+//
+//	r.DisableDefaultFilters()
+//	r.AddFilter(TeamKillFilter{r}, DuplicateKillFilter{r}, PlantBoundaryFilter{r})
+type TeamKillFilter struct {
+	Reader *Reader
+}
+
+func (f TeamKillFilter) Apply(prev []MatchUpdate, next MatchUpdate) (MatchUpdate, bool) {
+	if next.Type != Kill {
+		return next, true
+	}
+	killerIdx := f.Reader.PlayerIndexByUsername(next.Username)
+	targetIdx := f.Reader.PlayerIndexByUsername(next.Target)
+	if killerIdx < 0 || targetIdx < 0 {
+		return next, true
+	}
+	if f.Reader.Header.Players[killerIdx].TeamIndex == f.Reader.Header.Players[targetIdx].TeamIndex {
+		next.Type = TeamKill
+	}
+	return next, true
+}
+
+// DuplicateKillFilter drops a re-report of a kill/death the replay already
+// logged for the same target, unless the round clock says we're in
+// overtime - an overtime re-kill means a DBNO was finished off and so is
+// allowed through to PlantBoundaryFilter for a final check. The lookback is
+// scoped to the current round (f.Reader.roundStartIndex onward): a target
+// who already died in an earlier round must not match here, since that
+// round's Overtime state no longer applies once resetClock has run.
+type DuplicateKillFilter struct {
+	Reader *Reader
+}
+
+func (f DuplicateKillFilter) Apply(prev []MatchUpdate, next MatchUpdate) (MatchUpdate, bool) {
+	if next.Type != Kill && next.Type != Death {
+		return next, true
+	}
+	target := next.Target
+	if next.Type == Death {
+		target = next.Username
+	}
+	start := f.Reader.roundStartIndex
+	if start < 0 || start > len(prev) {
+		start = 0
+	}
+	for i := len(prev) - 1; i >= start; i-- {
+		val := prev[i]
+		if val.Type != Kill && val.Type != Death {
+			continue
+		}
+		targetAlreadyDead := (val.Type == Kill && val.Target == target) ||
+			(val.Type == Death && val.Username == target)
+		if !targetAlreadyDead {
+			continue
+		}
+		if f.Reader.clock.Overtime {
+			return next, true
+		}
+		log.Debug().
+			Str("killer", next.Username).
+			Str("target", target).
+			Float64("existing_time", val.TimeInSeconds).
+			Float64("new_time", next.TimeInSeconds).
+			Msg("duplicate kill filtered (target already dead)")
+		f.Reader.addDiagnostic(DiagDuplicateKillFiltered, Info, target)
+		return next, false
+	}
+	return next, true
+}
+
+// PlantBoundaryFilter catches the one duplicate DuplicateKillFilter lets
+// through during overtime: a re-report of the exact same kill, by the exact
+// same killer, logged at or within one second after the defuser plant. Real
+// DBNO finishes in overtime are by a different killer or happen later, so
+// this combination is always the replay re-emitting the original kill.
+type PlantBoundaryFilter struct {
+	Reader *Reader
+}
+
+func (f PlantBoundaryFilter) Apply(prev []MatchUpdate, next MatchUpdate) (MatchUpdate, bool) {
+	if next.Type != Kill || !f.Reader.clock.Overtime {
+		return next, true
+	}
+	plantTime := f.Reader.clock.PlantTime
+	if plantTime < 0 {
+		return next, true
+	}
+	for i := len(prev) - 1; i >= 0; i-- {
+		val := prev[i]
+		if val.Type != Kill || val.Target != next.Target || val.Username != next.Username {
+			continue
+		}
+		// Time counts down, so val.TimeInSeconds <= plantTime means the
+		// original kill happened at or after the plant.
+		if val.TimeInSeconds <= plantTime && val.TimeInSeconds >= plantTime-1 {
+			log.Debug().
+				Str("killer", next.Username).
+				Str("target", next.Target).
+				Float64("existing_time", val.TimeInSeconds).
+				Float64("new_time", next.TimeInSeconds).
+				Float64("defuser_plant_time", plantTime).
+				Msg("duplicate kill filtered (plant-boundary re-kill)")
+			f.Reader.addDiagnostic(DiagDuplicateKillFiltered, Info, next.Target)
+			return next, false
+		}
+		return next, true
+	}
+	return next, true
+}
+
+// defaultFilters is the chain readMatchFeedback runs every Kill through
+// unless DisableDefaultFilters has been called. Death updates (a kill with
+// an empty killer username) are appended without passing through any filter.
+func (r *Reader) defaultFilters() []FeedbackFilter {
+	return []FeedbackFilter{
+		SameTeamFilter{Reader: r},
+		DuplicateKillFilter{Reader: r},
+		PlantBoundaryFilter{Reader: r},
+	}
+}
+
+// AddFilter registers additional filters to run, in order, after the
+// default chain (or from the start if DisableDefaultFilters was called).
+func (r *Reader) AddFilter(filters ...FeedbackFilter) {
+	r.filters = append(r.filters, filters...)
+}
+
+// DisableDefaultFilters stops readMatchFeedback from running
+// SameTeamFilter/DuplicateKillFilter/PlantBoundaryFilter, leaving only
+// whatever has been registered with AddFilter.
+func (r *Reader) DisableDefaultFilters() {
+	r.defaultFiltersDisabled = true
+}
+
+// runFilters applies the effective filter chain to next, returning the
+// (possibly rewritten) update and whether it should be kept.
+func (r *Reader) runFilters(next MatchUpdate) (MatchUpdate, bool) {
+	chain := r.filters
+	if !r.defaultFiltersDisabled {
+		chain = append(r.defaultFilters(), chain...)
+	}
+	for _, f := range chain {
+		var ok bool
+		next, ok = f.Apply(r.MatchFeedback, next)
+		if !ok {
+			return next, false
+		}
+	}
+	return next, true
+}
@@ -0,0 +1,79 @@
+package dissect
+
+import "testing"
+
+func newTestReader() *Reader {
+	return &Reader{
+		Header: Header{
+			Players: []Player{
+				{Username: "alice", TeamIndex: 0},
+				{Username: "bob", TeamIndex: 0},
+				{Username: "eve", TeamIndex: 1},
+			},
+		},
+		clock: newRoundClock(),
+	}
+}
+
+// TestTeamKillFilter demonstrates swapping the default SameTeamFilter for
+// TeamKillFilter, per the doc comment on TeamKillFilter: a same-team kill is
+// retyped to TeamKill instead of being dropped, while an enemy kill is left
+// untouched.
+func TestTeamKillFilter(t *testing.T) {
+	r := newTestReader()
+	r.DisableDefaultFilters()
+	r.AddFilter(TeamKillFilter{Reader: r})
+
+	sameTeam, ok := r.runFilters(MatchUpdate{Type: Kill, Username: "alice", Target: "bob"})
+	if !ok {
+		t.Fatalf("TeamKillFilter should not drop a same-team kill, got dropped")
+	}
+	if sameTeam.Type != TeamKill {
+		t.Fatalf("expected same-team kill retyped to TeamKill, got %v", sameTeam.Type)
+	}
+
+	enemy, ok := r.runFilters(MatchUpdate{Type: Kill, Username: "alice", Target: "eve"})
+	if !ok || enemy.Type != Kill {
+		t.Fatalf("expected enemy kill to pass through as Kill, got type=%v ok=%v", enemy.Type, ok)
+	}
+}
+
+// TestSameTeamFilterDrops confirms the default chain still drops same-team
+// kills outright, the behavior TeamKillFilter is an opt-in alternative to.
+func TestSameTeamFilterDrops(t *testing.T) {
+	r := newTestReader()
+	_, ok := r.runFilters(MatchUpdate{Type: Kill, Username: "alice", Target: "bob"})
+	if ok {
+		t.Fatalf("expected default SameTeamFilter to drop a same-team kill")
+	}
+}
+
+// TestDuplicateKillFilterScopedToCurrentRound reproduces the cross-round
+// false positive: alice kills eve in round 1, then kills eve again in round
+// 2 (after the time jump resets the clock). Without round scoping,
+// DuplicateKillFilter mistakes the round 2 kill for a re-report of round
+// 1's and drops it.
+func TestDuplicateKillFilterScopedToCurrentRound(t *testing.T) {
+	r := newTestReader()
+	process := func(username, target string, at float64) {
+		if newRound := r.clock.observe(at); newRound {
+			r.resetClock()
+		}
+		u := MatchUpdate{Type: Kill, Username: username, Target: target, TimeInSeconds: at}
+		if filtered, ok := r.runFilters(u); ok {
+			r.appendUpdate(filtered)
+		}
+	}
+	process("alice", "eve", 120) // round 1
+	process("alice", "eve", 160) // round 2: upward jump with no plant yet -> new round
+
+	kills := 0
+	for _, u := range r.MatchFeedback {
+		if u.Type == Kill {
+			kills++
+		}
+	}
+	if kills != 2 {
+		t.Fatalf("expected 2 kills across two separate rounds, got %d: %+v", kills, r.MatchFeedback)
+	}
+}
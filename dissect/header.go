@@ -0,0 +1,53 @@
+package dissect
+
+// CodeVersion is the replay's game build, used to gate format differences
+// between seasons (e.g. the Y10S4 defuser-timer packet change).
+type CodeVersion int
+
+const (
+	Y8S4 CodeVersion = iota
+	Y9S1
+	Y9S1Update3
+	Y10S4
+)
+
+// TeamRole is a team's side for the round.
+type TeamRole int
+
+const (
+	Attack TeamRole = iota
+	Defense
+)
+
+// Operator is the in-game operator a player picked for the round.
+type Operator string
+
+// Header holds the replay's match-wide metadata: code version, roster and
+// teams, as read from the header packet before MatchFeedback begins.
+type Header struct {
+	CodeVersion CodeVersion `json:"codeVersion"`
+	Players     []Player    `json:"players"`
+	Teams       []Team      `json:"teams"`
+}
+
+// Player is one roster entry from Header.Players.
+type Player struct {
+	Username  string   `json:"username"`
+	TeamIndex int      `json:"teamIndex"`
+	Operator  Operator `json:"operator,omitempty"`
+	ID        []byte   `json:"-"`
+}
+
+// Team is one of the two teams playing the match.
+type Team struct {
+	Name string   `json:"name"`
+	Role TeamRole `json:"role"`
+}
+
+// stringerIntMarshal is the wire shape stringer-backed enums marshal to:
+// the human-readable name alongside the underlying int, so JSON consumers
+// get both without needing the Go type.
+type stringerIntMarshal struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
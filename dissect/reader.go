@@ -0,0 +1,119 @@
+package dissect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// Reader parses an r6-dissect replay, accumulating MatchFeedback and the
+// derived state (clock, filters, diagnostics, subscribers) the rest of the
+// package builds on top of.
+type Reader struct {
+	Header        Header
+	MatchFeedback []MatchUpdate
+
+	data   []byte
+	offset int
+
+	time    float64
+	timeRaw string
+
+	planted                   bool
+	lastDefuserPlayerIndex    int
+	lastKillerFromScoreboard  string
+	lastDefuserFromScoreboard string
+
+	subscribers []chan<- MatchUpdate
+	handlers    []EventHandler
+
+	clock           RoundClock
+	roundStartIndex int
+
+	filters                []FeedbackFilter
+	defaultFiltersDisabled bool
+
+	diagnostics []Diagnostic
+}
+
+// NewReader returns a Reader ready to parse data.
+func NewReader(data []byte) *Reader {
+	return &Reader{
+		data:                   data,
+		lastDefuserPlayerIndex: -1,
+		clock:                  newRoundClock(),
+	}
+}
+
+// Skip advances past n bytes without returning them.
+func (r *Reader) Skip(n int) error {
+	if r.offset+n > len(r.data) {
+		return errors.New("dissect: skip past end of data")
+	}
+	r.offset += n
+	return nil
+}
+
+// Bytes returns the next n bytes and advances past them.
+func (r *Reader) Bytes(n int) ([]byte, error) {
+	if r.offset+n > len(r.data) {
+		return nil, errors.New("dissect: read past end of data")
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b, nil
+}
+
+// Int reads a little-endian 32-bit integer.
+func (r *Reader) Int() (int, error) {
+	b, err := r.Bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.LittleEndian.Uint32(b)), nil
+}
+
+// String reads a 32-bit length-prefixed UTF-8 string.
+func (r *Reader) String() (string, error) {
+	n, err := r.Int()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.Bytes(n)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Seek advances the offset to just past the next occurrence of pattern.
+func (r *Reader) Seek(pattern []byte) error {
+	idx := bytes.Index(r.data[r.offset:], pattern)
+	if idx < 0 {
+		return errors.New("dissect: pattern not found")
+	}
+	r.offset += idx + len(pattern)
+	return nil
+}
+
+// PlayerIndexByUsername returns the index into Header.Players matching
+// username, or -1 if there is no such player.
+func (r *Reader) PlayerIndexByUsername(username string) int {
+	for i, p := range r.Header.Players {
+		if p.Username == username {
+			return i
+		}
+	}
+	return -1
+}
+
+// PlayerIndexByID returns the index into Header.Players whose ID matches
+// id, or -1 if there is no such player.
+func (r *Reader) PlayerIndexByID(id []byte) int {
+	for i, p := range r.Header.Players {
+		if bytes.Equal(p.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,100 @@
+package dissect
+
+// RoundPhase is where in a round's timeline the RoundClock currently is.
+type RoundPhase int
+
+const (
+	PhasePrep RoundPhase = iota
+	PhaseAction
+	PhasePlanted
+	PhaseOvertime
+)
+
+func (p RoundPhase) String() string {
+	switch p {
+	case PhasePrep:
+		return "Prep"
+	case PhaseAction:
+		return "Action"
+	case PhasePlanted:
+		return "Planted"
+	case PhaseOvertime:
+		return "Overtime"
+	default:
+		return "Unknown"
+	}
+}
+
+// RoundClock tracks the current round's phase so consumers don't have to
+// re-derive it from ad-hoc heuristics (time jumps, r.planted) scattered
+// across the feedback parser.
+type RoundClock struct {
+	Phase          RoundPhase
+	PhaseStartedAt float64
+	PlantTime      float64
+	DefuseDeadline float64
+	Overtime       bool
+
+	// lastTimeSeen is the previously observed TimeInSeconds; time counts
+	// down within a round, so a new timestamp well above it means the
+	// in-game clock reset, which only happens on a defuser-plant overtime
+	// reset or at the start of a new round.
+	lastTimeSeen float64
+}
+
+func newRoundClock() RoundClock {
+	return RoundClock{Phase: PhasePrep, PlantTime: -1, DefuseDeadline: -1, lastTimeSeen: -1}
+}
+
+// observe feeds a freshly parsed timestamp to the clock, ahead of any
+// duplicate/validity filtering, so overtime is detected from the raw
+// timeline rather than by rescanning MatchFeedback on every kill. It
+// reports newRound when the jump can't be explained by a defuser plant -
+// the clock only resets upward mid-round once the defuser is down, so any
+// other upward jump means a new round has started.
+func (c *RoundClock) observe(t float64) (newRound bool) {
+	if c.lastTimeSeen >= 0 && t > c.lastTimeSeen+5 {
+		if c.PlantTime >= 0 {
+			c.Overtime = true
+		} else {
+			newRound = true
+		}
+	}
+	c.lastTimeSeen = t
+	return newRound
+}
+
+// Clock returns the reader's current RoundClock.
+func (r *Reader) Clock() RoundClock {
+	return r.clock
+}
+
+// resetClock starts a fresh RoundClock and clears the per-round state that
+// should not carry over - r.planted and r.lastDefuserPlayerIndex were
+// tracking the previous round's defuser carrier, Overtime must not persist
+// once a new round has actually begun, and roundStartIndex lets filters
+// like DuplicateKillFilter scope their lookback to the current round
+// instead of the whole match.
+func (r *Reader) resetClock() {
+	r.clock = newRoundClock()
+	r.planted = false
+	r.lastDefuserPlayerIndex = -1
+	r.roundStartIndex = len(r.MatchFeedback)
+}
+
+// enterPhase transitions the clock to phase and emits a PhaseEnter
+// MatchUpdate through appendUpdate so subscribers see the transition
+// alongside kills and defuser events.
+func (r *Reader) enterPhase(phase RoundPhase) {
+	if r.clock.Phase == phase {
+		return
+	}
+	r.clock.Phase = phase
+	r.clock.PhaseStartedAt = r.time
+	r.appendUpdate(MatchUpdate{
+		Type:          PhaseEnter,
+		Message:       phase.String(),
+		Time:          r.timeRaw,
+		TimeInSeconds: r.time,
+	})
+}
@@ -0,0 +1,411 @@
+package dissect
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// RoundWinReason describes why a round ended.
+type RoundWinReason int
+
+const (
+	RoundWinUnknown RoundWinReason = iota
+	RoundWinElimination
+	RoundWinDefuserDetonated
+	RoundWinDefuserDisabled
+	RoundWinTime
+)
+
+func (r RoundWinReason) String() string {
+	switch r {
+	case RoundWinElimination:
+		return "Elimination"
+	case RoundWinDefuserDetonated:
+		return "DefuserDetonated"
+	case RoundWinDefuserDisabled:
+		return "DefuserDisabled"
+	case RoundWinTime:
+		return "Time"
+	default:
+		return "Unknown"
+	}
+}
+
+func (r RoundWinReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// StatsOptions configures how (*Reader).Stats aggregates MatchFeedback.
+type StatsOptions struct {
+	// TradeWindow is how soon after a teammate's death a kill on the same
+	// enemy counts as a trade kill.
+	TradeWindow time.Duration
+	// IncludeBots includes players without a recorded Username (bots/ghosts)
+	// in per-player aggregates.
+	IncludeBots bool
+}
+
+// DefaultStatsOptions mirrors the trade window most community stat sites use.
+var DefaultStatsOptions = StatsOptions{
+	TradeWindow: 5 * time.Second,
+}
+
+// PlayerRoundStats holds a single player's aggregates for one round.
+type PlayerRoundStats struct {
+	Username    string `json:"username"`
+	Kills       int    `json:"kills"`
+	Deaths      int    `json:"deaths"`
+	Headshots   int    `json:"headshots"`
+	FirstBlood  bool   `json:"firstBlood"`
+	FirstDeath  bool   `json:"firstDeath"`
+	TradeKills  int    `json:"tradeKills"`
+	OpeningDuel bool   `json:"openingDuel"`
+	Planted     bool   `json:"planted"`
+	Defused     bool   `json:"defused"`
+	Survived    bool   `json:"survived"`
+}
+
+// kost reports whether this player had a Kill, an Objective (plant/defuse),
+// a Survive, or a Trade this round - the standard KOST criterion.
+func (p PlayerRoundStats) kost() bool {
+	return p.Kills > 0 || p.TradeKills > 0 || p.Survived || p.Planted || p.Defused
+}
+
+// RoundStats aggregates a single round of MatchFeedback.
+type RoundStats struct {
+	Number    int                          `json:"number"`
+	WinReason RoundWinReason               `json:"winReason"`
+	PlantedBy string                       `json:"plantedBy,omitempty"`
+	DefusedBy string                       `json:"defusedBy,omitempty"`
+	Players   map[string]*PlayerRoundStats `json:"-"`
+}
+
+// PlayerStats holds a player's aggregates across the whole match.
+type PlayerStats struct {
+	Username     string  `json:"username"`
+	Kills        int     `json:"kills"`
+	Deaths       int     `json:"deaths"`
+	Headshots    int     `json:"headshots"`
+	HeadshotPct  float64 `json:"headshotPct"`
+	FirstBloods  int     `json:"firstBloods"`
+	FirstDeaths  int     `json:"firstDeaths"`
+	TradeKills   int     `json:"tradeKills"`
+	OpeningDuels int     `json:"openingDuels"`
+	Plants       int     `json:"plants"`
+	Defuses      int     `json:"defuses"`
+	KOST         int     `json:"kost"`
+	Rounds       int     `json:"rounds"`
+}
+
+// TeamStats holds a team's aggregates across the whole match.
+type TeamStats struct {
+	Name      string `json:"name"`
+	Kills     int    `json:"kills"`
+	Deaths    int    `json:"deaths"`
+	RoundsWon int    `json:"roundsWon"`
+}
+
+// MatchStats is the aggregated, round-by-round view of a parsed match.
+type MatchStats struct {
+	Rounds  []RoundStats            `json:"rounds"`
+	Players map[string]*PlayerStats `json:"-"`
+	Teams   map[string]*TeamStats   `json:"-"`
+}
+
+// matchStatsJSON is the stable, order-preserving wire shape for MatchStats.
+type matchStatsJSON struct {
+	Rounds  []RoundStats  `json:"rounds"`
+	Players []PlayerStats `json:"players"`
+	Teams   []TeamStats   `json:"teams"`
+}
+
+// MarshalJSON sorts the player/team maps into slices (by username/name) so
+// the emitted JSON is stable across runs.
+func (m MatchStats) MarshalJSON() ([]byte, error) {
+	out := matchStatsJSON{Rounds: m.Rounds}
+	for _, p := range m.Players {
+		out.Players = append(out.Players, *p)
+	}
+	sort.Slice(out.Players, func(i, j int) bool { return out.Players[i].Username < out.Players[j].Username })
+	for _, t := range m.Teams {
+		out.Teams = append(out.Teams, *t)
+	}
+	sort.Slice(out.Teams, func(i, j int) bool { return out.Teams[i].Name < out.Teams[j].Name })
+	return json.Marshal(out)
+}
+
+// Stats walks r.MatchFeedback alongside the header roster and produces
+// per-round, per-player and per-team aggregates.
+func (r *Reader) Stats(opts StatsOptions) MatchStats {
+	stats := MatchStats{
+		Players: make(map[string]*PlayerStats),
+		Teams:   make(map[string]*TeamStats),
+	}
+	for _, p := range r.Header.Players {
+		if p.Username == "" && !opts.IncludeBots {
+			continue
+		}
+		stats.Players[p.Username] = &PlayerStats{Username: p.Username}
+	}
+	for _, t := range r.Header.Teams {
+		stats.Teams[t.Name] = &TeamStats{Name: t.Name}
+	}
+
+	round := newRoundStats(1, stats.Players)
+	var roundEvents []MatchUpdate
+	// clock replays the same plant-aware jump detection RoundClock.observe
+	// uses live, so an overtime reset (jump after a defuser plant) doesn't
+	// get mistaken for a new round the way a bare time-delta check would.
+	clock := newRoundClock()
+	for _, u := range r.MatchFeedback {
+		if newRound := clock.observe(u.TimeInSeconds); newRound {
+			stats.Rounds = append(stats.Rounds, finalizeRound(round, r))
+			round = newRoundStats(round.Number+1, stats.Players)
+			roundEvents = nil
+			clock = newRoundClock()
+			clock.observe(u.TimeInSeconds)
+		}
+
+		switch u.Type {
+		case Kill:
+			r.recordKill(opts, round, roundEvents, u)
+		case Death:
+			r.recordDeath(round, u)
+		case DefuserPlantComplete:
+			round.PlantedBy = u.Username
+			clock.PlantTime = u.TimeInSeconds
+			if p := round.Players[u.Username]; p != nil {
+				p.Planted = true
+			}
+		case DefuserDisableComplete:
+			round.DefusedBy = u.Username
+			if p := round.Players[u.Username]; p != nil {
+				p.Defused = true
+			}
+		}
+		roundEvents = append(roundEvents, u)
+	}
+	stats.Rounds = append(stats.Rounds, finalizeRound(round, r))
+
+	for _, rnd := range stats.Rounds {
+		reason := rnd.WinReason
+		for username, rp := range rnd.Players {
+			p, ok := stats.Players[username]
+			if !ok {
+				continue
+			}
+			p.Rounds++
+			p.Kills += rp.Kills
+			p.Deaths += rp.Deaths
+			p.Headshots += rp.Headshots
+			p.TradeKills += rp.TradeKills
+			if rp.FirstBlood {
+				p.FirstBloods++
+			}
+			if rp.FirstDeath {
+				p.FirstDeaths++
+			}
+			if rp.OpeningDuel {
+				p.OpeningDuels++
+			}
+			if rp.Planted {
+				p.Plants++
+			}
+			if rp.Defused {
+				p.Defuses++
+			}
+			if rp.kost() {
+				p.KOST++
+			}
+
+			idx := r.PlayerIndexByUsername(username)
+			if idx < 0 || idx >= len(r.Header.Players) {
+				continue
+			}
+			team := r.teamNameByIndex(r.Header.Players[idx].TeamIndex)
+			if ts, ok := stats.Teams[team]; ok {
+				ts.Kills += rp.Kills
+				ts.Deaths += rp.Deaths
+			}
+		}
+		if winner := r.roundWinningTeam(reason); winner != "" {
+			if ts, ok := stats.Teams[winner]; ok {
+				ts.RoundsWon++
+			}
+		}
+	}
+	for _, p := range stats.Players {
+		if p.Kills+p.Deaths == 0 {
+			continue
+		}
+		p.HeadshotPct = float64(p.Headshots) / float64(max(p.Kills, 1)) * 100
+	}
+	return stats
+}
+
+func newRoundStats(number int, players map[string]*PlayerStats) RoundStats {
+	rp := make(map[string]*PlayerRoundStats, len(players))
+	for username := range players {
+		rp[username] = &PlayerRoundStats{Username: username, Survived: true}
+	}
+	return RoundStats{Number: number, Players: rp}
+}
+
+// finalizeRound infers the win reason from what happened during the round.
+func finalizeRound(round RoundStats, r *Reader) RoundStats {
+	switch {
+	case round.DefusedBy != "":
+		round.WinReason = RoundWinDefuserDisabled
+	case round.PlantedBy != "":
+		round.WinReason = RoundWinDefuserDetonated
+	case r.teamWipedOut(round):
+		round.WinReason = RoundWinElimination
+	default:
+		round.WinReason = RoundWinTime
+	}
+	return round
+}
+
+// teamWipedOut reports whether every tracked player on one of the two teams
+// died this round - a round can end in elimination with the other team
+// still holding survivors, so this checks per-team rather than requiring
+// every player in the round to be dead.
+func (r *Reader) teamWipedOut(round RoundStats) bool {
+	for teamIdx := range r.Header.Teams {
+		tracked, allDead := false, true
+		for username, p := range round.Players {
+			idx := r.PlayerIndexByUsername(username)
+			if idx < 0 || r.Header.Players[idx].TeamIndex != teamIdx {
+				continue
+			}
+			tracked = true
+			if p.Survived {
+				allDead = false
+				break
+			}
+		}
+		if tracked && allDead {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reader) recordKill(opts StatsOptions, round RoundStats, roundEvents []MatchUpdate, u MatchUpdate) {
+	killer := round.Players[u.Username]
+	target := round.Players[u.Target]
+	if killer == nil {
+		killer = &PlayerRoundStats{Username: u.Username}
+		round.Players[u.Username] = killer
+	}
+	killer.Kills++
+	if u.Headshot != nil && *u.Headshot {
+		killer.Headshots++
+	}
+	if target != nil {
+		target.Deaths++
+		target.Survived = false
+	}
+	r.markFirstAndOpening(round, killer, target)
+	r.markTradeKill(opts, roundEvents, killer, u)
+}
+
+func (r *Reader) recordDeath(round RoundStats, u MatchUpdate) {
+	target := round.Players[u.Username]
+	if target == nil {
+		target = &PlayerRoundStats{Username: u.Username}
+		round.Players[u.Username] = target
+	}
+	target.Deaths++
+	target.Survived = false
+	r.markFirstAndOpening(round, nil, target)
+}
+
+// markFirstAndOpening flags the first kill/death and opening duel of the round.
+func (r *Reader) markFirstAndOpening(round RoundStats, killer, target *PlayerRoundStats) {
+	for _, p := range round.Players {
+		if p.FirstBlood || p.FirstDeath {
+			return // someone already died this round
+		}
+	}
+	if killer != nil {
+		killer.FirstBlood = true
+		killer.OpeningDuel = true
+	}
+	if target != nil {
+		target.FirstDeath = true
+		target.OpeningDuel = true
+	}
+}
+
+// markTradeKill credits killer with a trade if u.Target killed a teammate of
+// killer within opts.TradeWindow earlier this round. roundEvents holds only
+// the current round's MatchFeedback seen so far (not the whole match), and
+// only the events strictly before u, so the scan never crosses a round
+// boundary or credits a trade against a different enemy.
+func (r *Reader) markTradeKill(opts StatsOptions, roundEvents []MatchUpdate, killer *PlayerRoundStats, u MatchUpdate) {
+	if killer == nil {
+		return
+	}
+	window := opts.TradeWindow
+	if window == 0 {
+		window = DefaultStatsOptions.TradeWindow
+	}
+	killerIdx := r.PlayerIndexByUsername(u.Username)
+	if killerIdx < 0 {
+		return
+	}
+	killerTeam := r.Header.Players[killerIdx].TeamIndex
+	for i := len(roundEvents) - 1; i >= 0; i-- {
+		prev := roundEvents[i]
+		// Time counts down during a round, so an earlier event has a
+		// larger TimeInSeconds; once the gap exceeds window, everything
+		// further back is even older.
+		if prev.TimeInSeconds-u.TimeInSeconds > window.Seconds() {
+			break
+		}
+		if prev.Type != Kill || prev.Username != u.Target {
+			continue // not a kill by the same enemy we just killed
+		}
+		idx := r.PlayerIndexByUsername(prev.Target)
+		if idx < 0 || r.Header.Players[idx].TeamIndex != killerTeam {
+			continue
+		}
+		killer.TradeKills++
+		return
+	}
+}
+
+func (r *Reader) teamNameByIndex(i int) string {
+	if i < 0 || i >= len(r.Header.Teams) {
+		return ""
+	}
+	return r.Header.Teams[i].Name
+}
+
+// roundWinningTeam maps a round's win reason to the team it favored: the
+// attacking team wins on detonation, the defending team on a disable.
+func (r *Reader) roundWinningTeam(reason RoundWinReason) string {
+	var role TeamRole
+	switch reason {
+	case RoundWinDefuserDetonated:
+		role = Attack
+	case RoundWinDefuserDisabled:
+		role = Defense
+	default:
+		return ""
+	}
+	idx := r.getTeamByRole(role)
+	if idx < 0 || idx >= len(r.Header.Teams) {
+		return ""
+	}
+	return r.Header.Teams[idx].Name
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
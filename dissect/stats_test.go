@@ -0,0 +1,157 @@
+package dissect
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func newTradeTestReader() *Reader {
+	return &Reader{
+		Header: Header{
+			Players: []Player{
+				{Username: "alice", TeamIndex: 0},
+				{Username: "bob", TeamIndex: 0},
+				{Username: "eve", TeamIndex: 1},
+			},
+			Teams: []Team{
+				{Name: "Attack", Role: Attack},
+				{Name: "Defense", Role: Defense},
+			},
+		},
+	}
+}
+
+// TestStatsTradeKillWindow covers the trade-window fix: alice's kill on eve
+// within TradeWindow of eve's kill on bob is credited as a trade, but an
+// identical kill outside the window is not.
+func TestStatsTradeKillWindow(t *testing.T) {
+	r := newTradeTestReader()
+	r.MatchFeedback = []MatchUpdate{
+		{Type: Kill, Username: "eve", Target: "bob", TimeInSeconds: 100},
+		{Type: Kill, Username: "alice", Target: "eve", TimeInSeconds: 97},
+	}
+	stats := r.Stats(StatsOptions{TradeWindow: 5 * time.Second})
+	if got := stats.Players["alice"].TradeKills; got != 1 {
+		t.Fatalf("expected 1 trade kill within window, got %d", got)
+	}
+
+	r = newTradeTestReader()
+	r.MatchFeedback = []MatchUpdate{
+		{Type: Kill, Username: "eve", Target: "bob", TimeInSeconds: 100},
+		{Type: Kill, Username: "alice", Target: "eve", TimeInSeconds: 90},
+	}
+	stats = r.Stats(StatsOptions{TradeWindow: 5 * time.Second})
+	if got := stats.Players["alice"].TradeKills; got != 0 {
+		t.Fatalf("expected 0 trade kills outside window, got %d", got)
+	}
+}
+
+// TestStatsTradeKillRequiresSameEnemy covers the same-enemy requirement: a
+// teammate death by a different enemy than the one killer just killed must
+// not be credited as a trade.
+func TestStatsTradeKillRequiresSameEnemy(t *testing.T) {
+	r := newTradeTestReader()
+	r.Header.Players = append(r.Header.Players, Player{Username: "mallory", TeamIndex: 1})
+	r.MatchFeedback = []MatchUpdate{
+		{Type: Kill, Username: "mallory", Target: "bob", TimeInSeconds: 100},
+		{Type: Kill, Username: "alice", Target: "eve", TimeInSeconds: 97},
+	}
+	stats := r.Stats(StatsOptions{TradeWindow: 5 * time.Second})
+	if got := stats.Players["alice"].TradeKills; got != 0 {
+		t.Fatalf("expected 0 trade kills against a different enemy, got %d", got)
+	}
+}
+
+// TestStatsEliminationIsPerTeam covers the elimination-detection fix: a
+// round where one team is wiped out is RoundWinElimination even though the
+// winning team still has a survivor.
+func TestStatsEliminationIsPerTeam(t *testing.T) {
+	r := newTradeTestReader()
+	r.MatchFeedback = []MatchUpdate{
+		{Type: Kill, Username: "eve", Target: "alice", TimeInSeconds: 100},
+		{Type: Kill, Username: "eve", Target: "bob", TimeInSeconds: 90},
+	}
+	stats := r.Stats(DefaultStatsOptions)
+	if len(stats.Rounds) != 1 {
+		t.Fatalf("expected 1 round, got %d", len(stats.Rounds))
+	}
+	if stats.Rounds[0].WinReason != RoundWinElimination {
+		t.Fatalf("expected RoundWinElimination, got %v", stats.Rounds[0].WinReason)
+	}
+}
+
+// TestStatsOvertimeRoundNotSplit covers the round-splitting fix: a defuser
+// plant followed by the overtime timer reset (jump upward) must stay one
+// round, not be split into a phantom second round, because RoundClock.observe
+// classifies a post-plant jump as Overtime rather than a new round.
+func TestStatsOvertimeRoundNotSplit(t *testing.T) {
+	r := newTradeTestReader()
+	r.MatchFeedback = []MatchUpdate{
+		{Type: DefuserPlantComplete, Username: "alice", TimeInSeconds: 8},
+		{Type: Kill, Username: "alice", Target: "eve", TimeInSeconds: 45},
+	}
+	stats := r.Stats(DefaultStatsOptions)
+	if len(stats.Rounds) != 1 {
+		t.Fatalf("expected 1 round across the overtime reset, got %d", len(stats.Rounds))
+	}
+	if got := stats.Players["alice"].Kills; got != 1 {
+		t.Fatalf("expected the overtime kill credited once, got %d", got)
+	}
+}
+
+// TestPlayerRoundStatsKOSTCountsObjective covers the KOST fix: a plant or
+// defuse with no kill, no trade and no survival still counts toward KOST.
+func TestPlayerRoundStatsKOSTCountsObjective(t *testing.T) {
+	p := PlayerRoundStats{Planted: true}
+	if !p.kost() {
+		t.Fatalf("expected a plant with no kill/trade/survive to satisfy KOST")
+	}
+	p = PlayerRoundStats{}
+	if p.kost() {
+		t.Fatalf("expected no KOST with no kill/objective/survive/trade")
+	}
+}
+
+// TestMatchStatsMarshalJSONRoundTrip covers the CLI-facing JSON export: the
+// Players/Teams maps (tagged json:"-") must survive the MarshalJSON -> Unmarshal
+// round trip as sorted, order-stable slices.
+func TestMatchStatsMarshalJSONRoundTrip(t *testing.T) {
+	stats := MatchStats{
+		Rounds: []RoundStats{{Number: 1, WinReason: RoundWinElimination}},
+		Players: map[string]*PlayerStats{
+			"bob":   {Username: "bob", Kills: 1},
+			"alice": {Username: "alice", Kills: 2},
+		},
+		Teams: map[string]*TeamStats{
+			"Attack": {Name: "Attack", RoundsWon: 1},
+		},
+	}
+
+	b, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var out struct {
+		Rounds []struct {
+			Number    int    `json:"number"`
+			WinReason string `json:"winReason"`
+		} `json:"rounds"`
+		Players []PlayerStats `json:"players"`
+		Teams   []TeamStats   `json:"teams"`
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if len(out.Rounds) != 1 || out.Rounds[0].WinReason != "Elimination" {
+		t.Fatalf("unexpected rounds in round trip: %+v", out.Rounds)
+	}
+	if len(out.Players) != 2 || out.Players[0].Username != "alice" || out.Players[1].Username != "bob" {
+		t.Fatalf("expected players sorted by username, got %+v", out.Players)
+	}
+	if len(out.Teams) != 1 || out.Teams[0].Name != "Attack" {
+		t.Fatalf("unexpected teams in round trip: %+v", out.Teams)
+	}
+}
@@ -0,0 +1,92 @@
+package dissect
+
+// EventHandler receives callbacks for each MatchUpdate as it is parsed,
+// letting a caller react to a replay while it is still being read instead of
+// waiting for Read() to return and inspecting r.MatchFeedback afterwards.
+//
+// Every method is optional to implement meaningfully; embed
+// BaseEventHandler to satisfy the interface with no-ops and only override
+// the callbacks you care about.
+type EventHandler interface {
+	OnKill(MatchUpdate)
+	OnDeath(MatchUpdate)
+	OnDefuserPlantStart(MatchUpdate)
+	OnDefuserPlantComplete(MatchUpdate)
+	OnDefuserDisableStart(MatchUpdate)
+	OnDefuserDisableComplete(MatchUpdate)
+	OnLocateObjective(MatchUpdate)
+	OnOperatorSwap(MatchUpdate)
+	OnPhaseEnter(MatchUpdate)
+	OnOther(MatchUpdate)
+}
+
+// BaseEventHandler implements EventHandler with no-ops so callers can embed
+// it and only override the callbacks they need.
+type BaseEventHandler struct{}
+
+func (BaseEventHandler) OnKill(MatchUpdate)                   {}
+func (BaseEventHandler) OnDeath(MatchUpdate)                  {}
+func (BaseEventHandler) OnDefuserPlantStart(MatchUpdate)      {}
+func (BaseEventHandler) OnDefuserPlantComplete(MatchUpdate)   {}
+func (BaseEventHandler) OnDefuserDisableStart(MatchUpdate)    {}
+func (BaseEventHandler) OnDefuserDisableComplete(MatchUpdate) {}
+func (BaseEventHandler) OnLocateObjective(MatchUpdate)        {}
+func (BaseEventHandler) OnOperatorSwap(MatchUpdate)           {}
+func (BaseEventHandler) OnPhaseEnter(MatchUpdate)             {}
+func (BaseEventHandler) OnOther(MatchUpdate)                  {}
+
+// Subscribe registers ch to receive every MatchUpdate as it is appended to
+// r.MatchFeedback. Sends are non-blocking: a subscriber that isn't keeping
+// up has updates dropped rather than stalling the parse. Callers that need
+// every update should use AddEventHandler instead, or buffer ch generously.
+func (r *Reader) Subscribe(ch chan<- MatchUpdate) {
+	r.subscribers = append(r.subscribers, ch)
+}
+
+// AddEventHandler registers h to be called synchronously for every
+// MatchUpdate as it is appended to r.MatchFeedback.
+func (r *Reader) AddEventHandler(h EventHandler) {
+	r.handlers = append(r.handlers, h)
+}
+
+// appendUpdate records u on r.MatchFeedback and notifies any subscribers
+// and event handlers registered via Subscribe/AddEventHandler. All parse
+// sites that previously appended to r.MatchFeedback directly should call
+// this instead so streaming consumers see events as they're discovered.
+func (r *Reader) appendUpdate(u MatchUpdate) {
+	r.MatchFeedback = append(r.MatchFeedback, u)
+	for _, ch := range r.subscribers {
+		select {
+		case ch <- u:
+		default:
+		}
+	}
+	for _, h := range r.handlers {
+		dispatchEvent(h, u)
+	}
+}
+
+func dispatchEvent(h EventHandler, u MatchUpdate) {
+	switch u.Type {
+	case Kill:
+		h.OnKill(u)
+	case Death:
+		h.OnDeath(u)
+	case DefuserPlantStart:
+		h.OnDefuserPlantStart(u)
+	case DefuserPlantComplete:
+		h.OnDefuserPlantComplete(u)
+	case DefuserDisableStart:
+		h.OnDefuserDisableStart(u)
+	case DefuserDisableComplete:
+		h.OnDefuserDisableComplete(u)
+	case LocateObjective:
+		h.OnLocateObjective(u)
+	case OperatorSwap:
+		h.OnOperatorSwap(u)
+	case PhaseEnter:
+		h.OnPhaseEnter(u)
+	default:
+		h.OnOther(u)
+	}
+}